@@ -1,27 +1,140 @@
-from flask import Flask, send_from_directory, render_template
+from flask import Flask, send_from_directory, render_template, request, abort
 import os
+import signal
+import threading
 
-app = Flask(__name__, static_folder='assets', template_folder='.')
+# Static files are served only through the explicit routes below
+app = Flask(__name__, static_folder=None, template_folder='.')
+
+def env_flag(name, default):
+    value = os.environ.get(name, default).strip().lower()
+    if value in ('1', 'true', 'yes', 'on'):
+        return True
+    if value in ('0', 'false', 'no', 'off'):
+        return False
+    raise ValueError(f'{name} must be a boolean (true/false), got {value!r}')
+
+# Root holding index.html, favicon.ico, src/ and assets/; set
+# SERVE_STATIC=false for API-only deployments
+STATIC_DIR = os.environ.get('STATIC_DIR', '.')
+SERVE_STATIC = env_flag('SERVE_STATIC', 'true')
+
+# Comma-separated list of origins allowed to read HTTP responses cross-origin
+CORS_ALLOWED_ORIGINS = [
+    origin.strip()
+    for origin in os.environ.get('CORS_ALLOWED_ORIGINS', '').split(',')
+    if origin.strip()
+]
+
+def load_build_info():
+    # Injected at image build time (see Dockerfile)
+    return {
+        'version': os.environ.get('APP_VERSION', 'dev'),
+        'commit': os.environ.get('APP_COMMIT', 'unknown'),
+        'buildDate': os.environ.get('APP_BUILD_DATE', 'unknown'),
+    }
+
+BUILD_INFO = load_build_info()
+
+# Seconds to keep serving after SIGTERM, with /readyz failing, so load
+# balancers stop routing here before the process exits
+DRAIN_SECONDS = float(os.environ.get('DRAIN_SECONDS', '0'))
+
+# Set once graceful shutdown begins so /readyz can report draining
+shutting_down = threading.Event()
+
+def _end_drain():
+    # Re-deliver SIGTERM so shutdown continues on the main thread
+    os.kill(os.getpid(), signal.SIGTERM)
+
+def _make_sigterm_handler(previous):
+    def handle_sigterm(signum, frame):
+        if not shutting_down.is_set():
+            shutting_down.set()
+            if DRAIN_SECONDS > 0:
+                timer = threading.Timer(DRAIN_SECONDS, _end_drain)
+                timer.daemon = True
+                timer.start()
+                return
+        # Hand over to whatever handled SIGTERM before us (gunicorn's worker
+        # exit handler, or the default) so shutdown itself is unchanged
+        if callable(previous):
+            previous(signum, frame)
+        elif previous != signal.SIG_IGN:
+            signal.signal(signal.SIGTERM, signal.SIG_DFL)
+            os.kill(os.getpid(), signal.SIGTERM)
+    return handle_sigterm
+
+def _install_shutdown_handler():
+    signal.signal(signal.SIGTERM, _make_sigterm_handler(signal.getsignal(signal.SIGTERM)))
+    # signal.signal() makes SIGTERM interrupt system calls again; gunicorn
+    # disables that so in-flight requests aren't disturbed, so restore it
+    if hasattr(signal, 'siginterrupt'):
+        signal.siginterrupt(signal.SIGTERM, False)
+
+# Signal handlers can only be installed from the main thread. Under gunicorn
+# --preload this runs in the master and every worker replaces the handler, so
+# draining only works in the default (non-preload) mode
+if threading.current_thread() is threading.main_thread():
+    _install_shutdown_handler()
+
+@app.after_request
+def add_cors_headers(response):
+    if not CORS_ALLOWED_ORIGINS:
+        return response
+    # Responses differ per origin, so shared caches must key on it even when
+    # no CORS headers are added
+    response.vary.add('Origin')
+    origin = request.headers.get('Origin')
+    if origin and ('*' in CORS_ALLOWED_ORIGINS or origin in CORS_ALLOWED_ORIGINS):
+        response.headers['Access-Control-Allow-Origin'] = origin
+        response.headers['Access-Control-Allow-Methods'] = 'GET, OPTIONS'
+        response.headers['Access-Control-Allow-Headers'] = 'Content-Type'
+        response.headers['Access-Control-Max-Age'] = '600'
+    return response
+
+def send_static(directory, path):
+    if not SERVE_STATIC:
+        abort(404)
+    # send_from_directory already refuses to escape the directory; also hide
+    # dotfiles (.env, .git/...) and reject any '..' segment outright
+    if any(part.startswith('.') for part in path.replace('\\', '/').split('/')):
+        abort(404)
+    return send_from_directory(os.path.join(STATIC_DIR, directory), path)
 
 @app.route('/')
 def index():
-    return send_from_directory('.', 'index.html')
+    return send_static('', 'index.html')
 
 @app.route('/src/<path:path>')
 def send_src(path):
-    return send_from_directory('src', path)
+    return send_static('src', path)
 
 @app.route('/assets/<path:path>')
 def send_assets(path):
-    return send_from_directory('assets', path)
+    return send_static('assets', path)
 
 @app.route('/health')
 def health():
-    return {'status': 'healthy'}, 200
+    return {'status': 'healthy', **BUILD_INFO}, 200
+
+@app.route('/livez')
+def livez():
+    return {'status': 'alive'}, 200
+
+@app.route('/readyz')
+def readyz():
+    if shutting_down.is_set():
+        return {'status': 'draining'}, 503
+    return {'status': 'ready'}, 200
+
+@app.route('/version')
+def version():
+    return BUILD_INFO, 200
 
 @app.route("/favicon.ico")
 def favicon():
-    return send_from_directory('.', 'favicon.ico')
+    return send_static('', 'favicon.ico')
 
 if __name__ == '__main__':
     port = int(os.environ.get('PORT', 5500))
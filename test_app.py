@@ -0,0 +1,197 @@
+import os
+import signal
+import tempfile
+import threading
+import unittest
+from unittest import mock
+
+import app as app_module
+
+
+class CorsTest(unittest.TestCase):
+    def setUp(self):
+        self.client = app_module.app.test_client()
+        patcher = mock.patch.object(
+            app_module, 'CORS_ALLOWED_ORIGINS', ['https://dashboard.example']
+        )
+        patcher.start()
+        self.addCleanup(patcher.stop)
+
+    def test_allowed_origin_gets_header(self):
+        response = self.client.get('/health', headers={'Origin': 'https://dashboard.example'})
+        self.assertEqual(response.headers.get('Access-Control-Allow-Origin'), 'https://dashboard.example')
+        self.assertIn('Origin', response.vary)
+
+    def test_other_origin_gets_no_header(self):
+        response = self.client.get('/health', headers={'Origin': 'https://evil.example'})
+        self.assertNotIn('Access-Control-Allow-Origin', response.headers)
+        self.assertIn('Origin', response.vary)
+
+    def test_no_origin_still_varies(self):
+        response = self.client.get('/health')
+        self.assertNotIn('Access-Control-Allow-Origin', response.headers)
+        self.assertIn('Origin', response.vary)
+
+    def test_preflight(self):
+        response = self.client.options('/health', headers={
+            'Origin': 'https://dashboard.example',
+            'Access-Control-Request-Method': 'GET',
+        })
+        self.assertEqual(response.status_code, 200)
+        self.assertEqual(response.headers.get('Access-Control-Allow-Origin'), 'https://dashboard.example')
+        self.assertIn('GET', response.headers.get('Access-Control-Allow-Methods'))
+
+    def test_no_allow_list_adds_nothing(self):
+        with mock.patch.object(app_module, 'CORS_ALLOWED_ORIGINS', []):
+            response = self.client.get('/health', headers={'Origin': 'https://dashboard.example'})
+        self.assertNotIn('Access-Control-Allow-Origin', response.headers)
+        self.assertNotIn('Origin', response.vary)
+
+
+class ProbeTest(unittest.TestCase):
+    def setUp(self):
+        self.client = app_module.app.test_client()
+        self.addCleanup(app_module.shutting_down.clear)
+
+    def test_livez(self):
+        response = self.client.get('/livez')
+        self.assertEqual(response.status_code, 200)
+        self.assertEqual(response.get_json(), {'status': 'alive'})
+
+    def test_readyz_ready(self):
+        response = self.client.get('/readyz')
+        self.assertEqual(response.status_code, 200)
+        self.assertEqual(response.get_json(), {'status': 'ready'})
+
+    def test_readyz_draining(self):
+        app_module.shutting_down.set()
+        response = self.client.get('/readyz')
+        self.assertEqual(response.status_code, 503)
+        self.assertEqual(response.get_json(), {'status': 'draining'})
+        # Liveness is unaffected by draining
+        self.assertEqual(self.client.get('/livez').status_code, 200)
+
+    def test_sigterm_drains_before_shutdown(self):
+        previous = mock.Mock()
+        handler = app_module._make_sigterm_handler(previous)
+        drained = threading.Event()
+
+        def end_drain():
+            handler(signal.SIGTERM, None)
+            drained.set()
+
+        with mock.patch.object(app_module, 'DRAIN_SECONDS', 0.2), \
+                mock.patch.object(app_module, '_end_drain', end_drain):
+            handler(signal.SIGTERM, None)
+            # Still serving, but no longer ready
+            self.assertEqual(self.client.get('/readyz').status_code, 503)
+            self.assertEqual(self.client.get('/livez').status_code, 200)
+            previous.assert_not_called()
+            self.assertTrue(drained.wait(5))
+        previous.assert_called_once_with(signal.SIGTERM, None)
+
+    def test_sigterm_without_drain_shuts_down_immediately(self):
+        previous = mock.Mock()
+        handler = app_module._make_sigterm_handler(previous)
+        with mock.patch.object(app_module, 'DRAIN_SECONDS', 0):
+            handler(signal.SIGTERM, None)
+        self.assertTrue(app_module.shutting_down.is_set())
+        previous.assert_called_once_with(signal.SIGTERM, None)
+
+
+class BuildInfoTest(unittest.TestCase):
+    def setUp(self):
+        self.client = app_module.app.test_client()
+
+    def test_defaults_when_unset(self):
+        env = {k: v for k, v in os.environ.items()
+               if k not in ('APP_VERSION', 'APP_COMMIT', 'APP_BUILD_DATE')}
+        with mock.patch.dict(os.environ, env, clear=True):
+            info = app_module.load_build_info()
+        self.assertEqual(info, {'version': 'dev', 'commit': 'unknown', 'buildDate': 'unknown'})
+
+    def test_reads_environment(self):
+        with mock.patch.dict(os.environ, {
+            'APP_VERSION': '1.2.3',
+            'APP_COMMIT': 'abc1234',
+            'APP_BUILD_DATE': '2025-01-01T00:00:00Z',
+        }):
+            info = app_module.load_build_info()
+        self.assertEqual(info, {'version': '1.2.3', 'commit': 'abc1234', 'buildDate': '2025-01-01T00:00:00Z'})
+
+    def test_health_includes_build_info(self):
+        info = {'version': 'dev', 'commit': 'unknown', 'buildDate': 'unknown'}
+        with mock.patch.object(app_module, 'BUILD_INFO', info):
+            response = self.client.get('/health')
+        self.assertEqual(response.status_code, 200)
+        self.assertEqual(response.get_json(), {'status': 'healthy', **info})
+
+    def test_version(self):
+        info = {'version': '1.2.3', 'commit': 'abc1234', 'buildDate': '2025-01-01T00:00:00Z'}
+        with mock.patch.object(app_module, 'BUILD_INFO', info):
+            response = self.client.get('/version')
+        self.assertEqual(response.status_code, 200)
+        self.assertEqual(response.get_json(), info)
+
+
+class StaticFilesTest(unittest.TestCase):
+    def setUp(self):
+        self.client = app_module.app.test_client()
+        tmp = tempfile.TemporaryDirectory()
+        self.addCleanup(tmp.cleanup)
+        # The configured root is <tmp>/static; <tmp> itself mirrors its layout
+        # with different content, so serving from the wrong root is detectable
+        self.root = os.path.join(tmp.name, 'static')
+        for base, marker in ((self.root, 'configured'), (tmp.name, 'outside')):
+            os.makedirs(os.path.join(base, 'src'), exist_ok=True)
+            with open(os.path.join(base, 'index.html'), 'w') as f:
+                f.write(f'<html>{marker}</html>')
+            with open(os.path.join(base, 'src', 'game.js'), 'w') as f:
+                f.write(f'// {marker}')
+        with open(os.path.join(tmp.name, 'outside.txt'), 'w') as f:
+            f.write('secret')
+        with open(os.path.join(self.root, 'src', '.env'), 'w') as f:
+            f.write('SECRET=1')
+        os.makedirs(os.path.join(self.root, 'assets', '.git'))
+        with open(os.path.join(self.root, 'assets', '.git', 'config'), 'w') as f:
+            f.write('[core]')
+        patcher = mock.patch.object(app_module, 'STATIC_DIR', self.root)
+        patcher.start()
+        self.addCleanup(patcher.stop)
+
+    def test_serves_from_configured_dir(self):
+        response = self.client.get('/')
+        self.assertEqual(response.status_code, 200)
+        self.assertEqual(response.get_data(as_text=True), '<html>configured</html>')
+        response = self.client.get('/src/game.js')
+        self.assertEqual(response.status_code, 200)
+        self.assertEqual(response.get_data(as_text=True), '// configured')
+
+    def test_files_outside_configured_dir_not_served(self):
+        self.assertEqual(self.client.get('/src/../../outside.txt').status_code, 404)
+
+    def test_traversal_rejected(self):
+        self.assertEqual(self.client.get('/src/../index.html').status_code, 404)
+        self.assertEqual(self.client.get('/assets/..%2f..%2foutside.txt').status_code, 404)
+
+    def test_dotfiles_rejected(self):
+        self.assertEqual(self.client.get('/src/.env').status_code, 404)
+        self.assertEqual(self.client.get('/assets/.git/config').status_code, 404)
+
+    def test_disabled(self):
+        with mock.patch.object(app_module, 'SERVE_STATIC', False):
+            self.assertEqual(self.client.get('/').status_code, 404)
+            self.assertEqual(self.client.get('/src/game.js').status_code, 404)
+            self.assertEqual(self.client.get('/health').status_code, 200)
+
+    def test_serve_static_flag_parsing(self):
+        for value, expected in (('true', True), ('on', True), ('false', False), ('OFF', False), ('0', False)):
+            with mock.patch.dict(os.environ, {'SERVE_STATIC': value}):
+                self.assertIs(app_module.env_flag('SERVE_STATIC', 'true'), expected)
+        with mock.patch.dict(os.environ, {'SERVE_STATIC': 'flase'}):
+            with self.assertRaises(ValueError):
+                app_module.env_flag('SERVE_STATIC', 'true')
+
+
+if __name__ == '__main__':
+    unittest.main()